@@ -2,25 +2,38 @@ package main
 
 import (
 	"bytes"
+	"container/list"
 	_ "embed"
 	"fmt"
 	"image"
-	_ "image/jpeg"
-	_ "image/png"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/disintegration/imaging"
 	"github.com/go-sam/args"
 	"github.com/go-sam/colours"
 	tw "github.com/go-sam/typewriter"
-	"github.com/nfnt/resize"
 )
 
 var asciiChars = " .:-=+*#"
 var randColourMap [8][3]uint8
 
+// The palette currently selected via --palette or --palette-file, used by
+// processPixel when colourMode is Palette.
+var activePalette color.Palette
+
+// Caches the nearest palette match per 8-bit RGB triple so repeated pixel
+// values (very common in flat-shaded or posterized source images) skip
+// the linear palette scan.
+var paletteLookup = newPaletteCache(4096)
+
 type ColourMode int
 
 const (
@@ -28,6 +41,7 @@ const (
 	Posterized
 	Colourful
 	Random
+	Palette
 )
 
 type MirrorMode int
@@ -39,18 +53,140 @@ const (
 	MirrorXY
 )
 
+// Charset selects how a resized image is turned into terminal cells.
+type Charset int
+
+const (
+	CharsetASCII Charset = iota
+	CharsetBraille
+	CharsetHalfblock
+)
+
+// Charsets selectable with --charset <name>.
+var charsets = map[string]Charset{
+	"ascii":     CharsetASCII,
+	"braille":   CharsetBraille,
+	"halfblock": CharsetHalfblock,
+}
+
+// OutputFormat selects how a rendered frame is encoded for a Sink.
+type OutputFormat int
+
+const (
+	FormatANSI OutputFormat = iota
+	FormatHTML
+	FormatSVG
+	FormatTXT
+)
+
+// Output formats selectable with --format <name>.
+var outputFormats = map[string]OutputFormat{
+	"ansi": FormatANSI,
+	"html": FormatHTML,
+	"svg":  FormatSVG,
+	"txt":  FormatTXT,
+}
+
 // Holds all configuration options for the ASCII art generator.
 //
 // Contains settings for input source, output dimensions, color rendering,
 // display behavior, and typewriter animation speed.
 type Config struct {
-	imageFile  string
-	folderPath string
-	imageWidth int
-	colourMode ColourMode
-	loopMode   bool
-	printSpeed int
-	mirrorMode MirrorMode
+	imageFile      string
+	folderPath     string
+	imageWidth     int
+	colourMode     ColourMode
+	loopMode       bool
+	printSpeed     int
+	mirrorMode     MirrorMode
+	resampleFilter imaging.ResampleFilter
+
+	// Pre-ASCII adjustment pipeline, applied in this order after resize.
+	brightness float64 // -100..100
+	contrast   float64 // -100..100
+	gamma      float64 // >0, 1 is neutral
+	saturation float64 // -100..100
+	sharpen    float64 // Gaussian sigma
+	blur       float64 // Gaussian sigma
+
+	edgesMode     bool
+	edgeThreshold float64
+
+	charset Charset
+
+	// Luminance (0..255) above which a braille dot is set. Negative means
+	// "compute per-image with Otsu's method" rather than a fixed value.
+	brailleThreshold float64
+
+	// Output sink. An empty outputPath means "print to the terminal",
+	// in which case outputFormat is ignored.
+	outputPath   string
+	outputFormat OutputFormat
+}
+
+// Resample filters selectable with --filter <name>.
+var resampleFilters = map[string]imaging.ResampleFilter{
+	"lanczos":         imaging.Lanczos,
+	"catmullrom":      imaging.CatmullRom,
+	"linear":          imaging.Linear,
+	"nearestneighbor": imaging.NearestNeighbor,
+	"box":             imaging.Box,
+}
+
+// Named palettes selectable with --palette <name>. Colours are listed in
+// their conventional display order for each palette.
+var namedPalettes = map[string]color.Palette{
+	"cga": {
+		color.RGBA{0x00, 0x00, 0x00, 0xff}, color.RGBA{0x00, 0x00, 0xaa, 0xff},
+		color.RGBA{0x00, 0xaa, 0x00, 0xff}, color.RGBA{0x00, 0xaa, 0xaa, 0xff},
+		color.RGBA{0xaa, 0x00, 0x00, 0xff}, color.RGBA{0xaa, 0x00, 0xaa, 0xff},
+		color.RGBA{0xaa, 0x55, 0x00, 0xff}, color.RGBA{0xaa, 0xaa, 0xaa, 0xff},
+		color.RGBA{0x55, 0x55, 0x55, 0xff}, color.RGBA{0x55, 0x55, 0xff, 0xff},
+		color.RGBA{0x55, 0xff, 0x55, 0xff}, color.RGBA{0x55, 0xff, 0xff, 0xff},
+		color.RGBA{0xff, 0x55, 0x55, 0xff}, color.RGBA{0xff, 0x55, 0xff, 0xff},
+		color.RGBA{0xff, 0xff, 0x55, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff},
+	},
+	// EGA's default 16-colour palette matches CGA's.
+	"ega": {
+		color.RGBA{0x00, 0x00, 0x00, 0xff}, color.RGBA{0x00, 0x00, 0xaa, 0xff},
+		color.RGBA{0x00, 0xaa, 0x00, 0xff}, color.RGBA{0x00, 0xaa, 0xaa, 0xff},
+		color.RGBA{0xaa, 0x00, 0x00, 0xff}, color.RGBA{0xaa, 0x00, 0xaa, 0xff},
+		color.RGBA{0xaa, 0x55, 0x00, 0xff}, color.RGBA{0xaa, 0xaa, 0xaa, 0xff},
+		color.RGBA{0x55, 0x55, 0x55, 0xff}, color.RGBA{0x55, 0x55, 0xff, 0xff},
+		color.RGBA{0x55, 0xff, 0x55, 0xff}, color.RGBA{0x55, 0xff, 0xff, 0xff},
+		color.RGBA{0xff, 0x55, 0x55, 0xff}, color.RGBA{0xff, 0x55, 0xff, 0xff},
+		color.RGBA{0xff, 0xff, 0x55, 0xff}, color.RGBA{0xff, 0xff, 0xff, 0xff},
+	},
+	"gruvbox": {
+		color.RGBA{0x28, 0x28, 0x28, 0xff}, color.RGBA{0xcc, 0x24, 0x1d, 0xff},
+		color.RGBA{0x98, 0x97, 0x1a, 0xff}, color.RGBA{0xd7, 0x99, 0x21, 0xff},
+		color.RGBA{0x45, 0x85, 0x88, 0xff}, color.RGBA{0xb1, 0x62, 0x86, 0xff},
+		color.RGBA{0x68, 0x9d, 0x6a, 0xff}, color.RGBA{0xa8, 0x99, 0x84, 0xff},
+		color.RGBA{0x92, 0x83, 0x74, 0xff}, color.RGBA{0xfb, 0x49, 0x34, 0xff},
+		color.RGBA{0xb8, 0xbb, 0x26, 0xff}, color.RGBA{0xfa, 0xbd, 0x2f, 0xff},
+		color.RGBA{0x83, 0xa5, 0x98, 0xff}, color.RGBA{0xd3, 0x86, 0x9b, 0xff},
+		color.RGBA{0x8e, 0xc0, 0x7c, 0xff}, color.RGBA{0xeb, 0xdb, 0xb2, 0xff},
+	},
+	"solarized": {
+		color.RGBA{0x00, 0x2b, 0x36, 0xff}, color.RGBA{0x07, 0x36, 0x42, 0xff},
+		color.RGBA{0x58, 0x6e, 0x75, 0xff}, color.RGBA{0x65, 0x7b, 0x83, 0xff},
+		color.RGBA{0x83, 0x94, 0x96, 0xff}, color.RGBA{0x93, 0xa1, 0xa1, 0xff},
+		color.RGBA{0xee, 0xe8, 0xd5, 0xff}, color.RGBA{0xfd, 0xf6, 0xe3, 0xff},
+		color.RGBA{0xb5, 0x89, 0x00, 0xff}, color.RGBA{0xcb, 0x4b, 0x16, 0xff},
+		color.RGBA{0xdc, 0x32, 0x2f, 0xff}, color.RGBA{0xd3, 0x36, 0x82, 0xff},
+		color.RGBA{0x6c, 0x71, 0xc4, 0xff}, color.RGBA{0x26, 0x8b, 0xd2, 0xff},
+		color.RGBA{0x2a, 0xa1, 0x98, 0xff}, color.RGBA{0x85, 0x99, 0x00, 0xff},
+	},
+	"nord": {
+		color.RGBA{0x2e, 0x34, 0x40, 0xff}, color.RGBA{0x3b, 0x42, 0x52, 0xff},
+		color.RGBA{0x43, 0x4c, 0x5e, 0xff}, color.RGBA{0x4c, 0x56, 0x6a, 0xff},
+		color.RGBA{0xd8, 0xde, 0xe9, 0xff}, color.RGBA{0xe5, 0xe9, 0xf0, 0xff},
+		color.RGBA{0xec, 0xef, 0xf4, 0xff}, color.RGBA{0x8f, 0xbc, 0xbb, 0xff},
+		color.RGBA{0x88, 0xc0, 0xd0, 0xff}, color.RGBA{0x81, 0xa1, 0xc1, 0xff},
+		color.RGBA{0x5e, 0x81, 0xac, 0xff}, color.RGBA{0xbf, 0x61, 0x6a, 0xff},
+		color.RGBA{0xd0, 0x87, 0x70, 0xff}, color.RGBA{0xeb, 0xcb, 0x8b, 0xff},
+		color.RGBA{0xa3, 0xbe, 0x8c, 0xff}, color.RGBA{0xb4, 0x8e, 0xad, 0xff},
+	},
 }
 
 //go:embed portrait_kim_kitsuragi.png
@@ -69,20 +205,29 @@ func main() {
 // Processes a single image file.
 //
 // Loads the image, resizes it, and either prints it once or continuously
-// loops based on the loop mode setting.
+// loops based on the loop mode setting. When --output is set, loop mode is
+// ignored and the image is written once, matching the GIF path's handling
+// of --loop with --output.
 func handleSingleImage(config Config) {
+	if isGIF(config.imageFile) {
+		if anim, err := loadAnimatedImage(config.imageFile); err == nil && len(anim.frames) > 1 {
+			playAnimation(config, anim)
+			return
+		}
+	}
+
 	img, err := loadImage(config.imageFile)
 	if err != nil {
 		return
 	}
 
-	resized := resizeImage(img, config.imageWidth)
+	resized := adjustImage(resizeImage(img, config.imageWidth, config.resampleFilter, config.charset), config)
 
-	if config.loopMode {
+	if config.loopMode && config.outputPath == "" {
 		printImageLoop(config, resized)
 	}
 
-	printImage(config, resized)
+	printImage(config, resized, "")
 }
 
 // Processes all images in a folder.
@@ -101,11 +246,56 @@ func handleMultipleImages(config Config) {
 	}
 }
 
-// Converts an image to ASCII art and displays it with typewriter effect.
+// A Sink consumes one fully-rendered frame.
+type Sink interface {
+	Write(frame []byte) error
+}
+
+// The default sink: types the frame out to the terminal via the
+// typewriter effect. Only meaningful for ANSI frames.
+type StdoutSink struct {
+	Speed int
+}
+
+func (s StdoutSink) Write(frame []byte) error {
+	t := tw.Typewriter{Text: string(frame), Speed: s.Speed}
+	t.Type()
+
+	return nil
+}
+
+// Writes a frame to a file on disk, replacing it if it already exists.
+type FileSink struct {
+	Path string
+}
+
+func (s FileSink) Write(frame []byte) error {
+	return os.WriteFile(s.Path, frame, 0o644)
+}
+
+// Picks the sink for one rendered frame. An empty outputPath keeps the
+// current interactive behaviour; otherwise frames are written to files,
+// with suffix distinguishing multiple frames (e.g. --folder galleries)
+// sharing one --output path so later frames don't overwrite earlier ones.
+func outputSink(config Config, suffix string) Sink {
+	if config.outputPath == "" {
+		return StdoutSink{Speed: 1000 / config.printSpeed}
+	}
+
+	path := config.outputPath
+	if suffix != "" {
+		ext := filepath.Ext(path)
+		path = strings.TrimSuffix(path, ext) + suffix + ext
+	}
+
+	return FileSink{Path: path}
+}
+
+// Converts an image to ASCII art and writes it to the configured sink.
 //
-// Regenerates random colors if in Random mode, then uses the typewriter
-// package to print the ASCII art at the specified speed.
-func printImage(config Config, img image.Image) {
+// Regenerates random colors if in Random mode. suffix disambiguates
+// multiple frames sharing one --output path (see outputSink).
+func printImage(config Config, img image.Image, suffix string) {
 	if config.colourMode == Random {
 		generateRandColourMap()
 	}
@@ -114,75 +304,289 @@ func printImage(config Config, img image.Image) {
 		img = mirrorImage(img, config.mirrorMode)
 	}
 
-	t := tw.Typewriter{Text: imageToASCII(img, config.colourMode), Speed: 1000 / config.printSpeed}
-	t.Type()
+	if err := outputSink(config, suffix).Write(renderImage(img, config)); err != nil {
+		fmt.Printf("Error: %s\n", err)
+	}
 }
 
 func printImageLoop(config Config, img image.Image) {
 	for {
-		printImage(config, img)
+		printImage(config, img, "")
 		fmt.Println()
 		time.Sleep(1 * time.Second)
 	}
 }
 
+// Clears the terminal and returns the cursor to the top-left corner, used
+// to redraw animation frames in place instead of scrolling the terminal.
+const ansiCursorHome = "\x1b[H\x1b[2J"
+
+// Holds a decoded multi-frame GIF plus the playback metadata needed to
+// render it frame-by-frame in the terminal.
+type AnimatedImage struct {
+	frames    []image.Image
+	delays    []int // hundredths of a second, matches gif.GIF.Delay
+	loopCount int   // matches gif.GIF.LoopCount
+}
+
+// Reports whether filename has a .gif extension.
+func isGIF(filename string) bool {
+	return strings.ToLower(filepath.Ext(filename)) == ".gif"
+}
+
+// Decodes every frame of a GIF and composites them onto a persistent
+// canvas the size of the GIF's logical screen, honoring each frame's
+// disposal method, so each returned frame is a complete image ready for
+// resizing and ASCII conversion.
+func loadAnimatedImage(filename string) (*AnimatedImage, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	g, err := gif.DecodeAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, g.Config.Width, g.Config.Height))
+	anim := &AnimatedImage{loopCount: g.LoopCount}
+
+	for i, frame := range g.Image {
+		var previous *image.RGBA
+		if g.Disposal[i] == gif.DisposalPrevious {
+			previous = image.NewRGBA(canvas.Bounds())
+			draw.Draw(previous, previous.Bounds(), canvas, image.Point{}, draw.Src)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		snapshot := image.NewRGBA(canvas.Bounds())
+		draw.Draw(snapshot, snapshot.Bounds(), canvas, image.Point{}, draw.Src)
+		anim.frames = append(anim.frames, snapshot)
+		anim.delays = append(anim.delays, g.Delay[i])
+
+		switch g.Disposal[i] {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			draw.Draw(canvas, canvas.Bounds(), previous, image.Point{}, draw.Src)
+		}
+	}
+
+	return anim, nil
+}
+
+// Plays a decoded GIF frame-by-frame in the terminal, resizing and
+// converting each frame to ASCII and resetting the cursor between frames
+// so the animation redraws in place rather than scrolling.
+//
+// The -l/--loop flag forces indefinite looping; otherwise the GIF's own
+// LoopCount decides how many times it plays (-1 means play once). When
+// --output is set, loop/delay timing is ignored and every frame is
+// instead written once to its own file, numbered by frame index.
+func playAnimation(config Config, anim *AnimatedImage) {
+	if config.colourMode == Random {
+		generateRandColourMap()
+	}
+
+	if config.outputPath != "" {
+		for i, frame := range anim.frames {
+			resized := adjustImage(resizeImage(frame, config.imageWidth, config.resampleFilter, config.charset), config)
+			if config.mirrorMode != MirrorNone {
+				resized = mirrorImage(resized, config.mirrorMode)
+			}
+
+			suffix := fmt.Sprintf("-%d", i)
+			if err := outputSink(config, suffix).Write(renderImage(resized, config)); err != nil {
+				fmt.Printf("Error: %s\n", err)
+			}
+		}
+
+		return
+	}
+
+	playOnce := func() {
+		for i, frame := range anim.frames {
+			resized := adjustImage(resizeImage(frame, config.imageWidth, config.resampleFilter, config.charset), config)
+			if config.mirrorMode != MirrorNone {
+				resized = mirrorImage(resized, config.mirrorMode)
+			}
+
+			fmt.Print(ansiCursorHome)
+			fmt.Print(renderASCII(resized, config))
+			time.Sleep(time.Duration(anim.delays[i]) * 10 * time.Millisecond)
+		}
+	}
+
+	if config.loopMode || anim.loopCount == 0 {
+		for {
+			playOnce()
+		}
+	}
+
+	plays := 1
+	if anim.loopCount > 0 {
+		plays = anim.loopCount + 1
+	}
+
+	for i := 0; i < plays; i++ {
+		playOnce()
+	}
+}
+
 // Processes and displays multiple images in sequence.
 //
 // Loads each image, resizes it, converts to ASCII art, and prints with
 // a 1-second delay between images. Skips any images that fail to load.
+// When writing to a file, each frame is instead suffixed with its source
+// image's base name and written as fast as possible, with no delay between
+// frames, so a whole folder can be dumped to disk as a gallery in one run.
+// GIFs in the folder play through once frame-by-frame (or, when writing to
+// a file, have every frame written under that image's suffix) instead of
+// being loaded as a single static frame.
 func printImages(config Config, imageFiles []string) {
 	for _, file := range imageFiles {
+		base := filepath.Base(file)
+		suffix := "-" + strings.TrimSuffix(base, filepath.Ext(base))
+
+		if isGIF(file) {
+			if anim, err := loadAnimatedImage(file); err == nil && len(anim.frames) > 1 {
+				printAnimationFrames(config, anim, suffix)
+
+				if config.outputPath == "" {
+					fmt.Println()
+					time.Sleep(1 * time.Second)
+				}
+
+				continue
+			}
+		}
+
 		img, err := loadImage(file)
 
 		if err != nil {
 			continue
 		}
 
-		resized := resizeImage(img, config.imageWidth)
-		printImage(config, resized)
+		resized := adjustImage(resizeImage(img, config.imageWidth, config.resampleFilter, config.charset), config)
+		printImage(config, resized, suffix)
 
-		fmt.Println()
-		time.Sleep(1 * time.Second)
+		if config.outputPath == "" {
+			fmt.Println()
+			time.Sleep(1 * time.Second)
+		}
+	}
+}
+
+// Plays one decoded GIF's frames for folder mode: written to their own
+// frame-numbered file under suffix when --output is set, or played once
+// frame-by-frame in place otherwise.
+func printAnimationFrames(config Config, anim *AnimatedImage, suffix string) {
+	if config.colourMode == Random {
+		generateRandColourMap()
+	}
+
+	for i, frame := range anim.frames {
+		resized := adjustImage(resizeImage(frame, config.imageWidth, config.resampleFilter, config.charset), config)
+		if config.mirrorMode != MirrorNone {
+			resized = mirrorImage(resized, config.mirrorMode)
+		}
+
+		if config.outputPath != "" {
+			frameSuffix := fmt.Sprintf("%s-%d", suffix, i)
+			if err := outputSink(config, frameSuffix).Write(renderImage(resized, config)); err != nil {
+				fmt.Printf("Error: %s\n", err)
+			}
+			continue
+		}
+
+		fmt.Print(ansiCursorHome)
+		fmt.Print(renderASCII(resized, config))
+		time.Sleep(time.Duration(anim.delays[i]) * 10 * time.Millisecond)
 	}
 }
 
 // Kim Kitsuragi
 func loadEmbeddedImage() (image.Image, error) {
-	img, _, err := image.Decode(bytes.NewReader(defaultImageData))
-	return img, err
+	return imaging.Decode(bytes.NewReader(defaultImageData), imaging.AutoOrientation(true))
 }
 
 // Loads an image from a file or returns the embedded default image.
 //
 // If filename is "default" or empty, loads the embedded Kim Kitsuragi portrait.
-// Otherwise opens and decodes the specified image file (supports PNG/JPEG).
+// Otherwise opens and decodes the specified image file (supports PNG/JPEG),
+// correcting portrait-oriented photos whose EXIF orientation tag says they
+// should be rotated rather than rendered as stored.
 func loadImage(filename string) (image.Image, error) {
 	if filename == "default" || filename == "" {
 		return loadEmbeddedImage()
 	}
 
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-	img, _, err := image.Decode(file)
-
-	return img, err
+	return imaging.Open(filename, imaging.AutoOrientation(true))
 }
 
 // Resizes an image to the specified width while maintaining aspect ratio.
 //
-// Height is calculated proportionally and divided by 2 to account for ASCII character
-// aspect ratio, using Lanczos3 interpolation for high quality resizing.
-func resizeImage(img image.Image, width int) image.Image {
+// height is the proportional height if one resized pixel mapped to one
+// terminal row; how it's then scaled depends on how many source pixels
+// the charset packs into a single cell. The ascii backend puts one pixel
+// in one cell and halves height to compensate for characters being roughly
+// twice as tall as they are wide. The halfblock backend already packs two
+// vertical pixels (fg/bg) per cell, so it needs the un-halved height.
+// The braille backend packs a 2x4 dot grid per cell, so it gets twice the
+// ascii backend's pixel columns and four times its pixel rows, which gives
+// the same number of cells.
+func resizeImage(img image.Image, width int, filter imaging.ResampleFilter, charset Charset) image.Image {
 	bounds := img.Bounds()
 	originalWidth := bounds.Dx()
 	originalHeight := bounds.Dy()
 
-	height := uint((originalHeight * width) / originalWidth / 2)
+	height := (originalHeight * width) / originalWidth
+
+	switch charset {
+	case CharsetBraille:
+		return imaging.Resize(img, width*2, height*2, filter)
+	case CharsetHalfblock:
+		return imaging.Resize(img, width, height, filter)
+	default:
+		return imaging.Resize(img, width, height/2, filter)
+	}
+}
+
+// Runs the pre-ASCII adjustment pipeline configured via CLI flags, in
+// brightness/contrast/gamma/saturation/sharpen/blur order, after resizing
+// and before ASCII conversion. The 8-char ASCII ramp compresses tonal
+// range brutally, so boosting contrast or lifting shadows here noticeably
+// improves legibility of dark or flat source photos. Each step is skipped
+// when left at its neutral value.
+func adjustImage(img image.Image, config Config) image.Image {
+	if config.brightness != 0 {
+		img = imaging.AdjustBrightness(img, config.brightness)
+	}
+
+	if config.contrast != 0 {
+		img = imaging.AdjustContrast(img, config.contrast)
+	}
+
+	if config.gamma != 1 {
+		img = imaging.AdjustGamma(img, config.gamma)
+	}
+
+	if config.saturation != 0 {
+		img = imaging.AdjustSaturation(img, config.saturation)
+	}
+
+	if config.sharpen != 0 {
+		img = imaging.Sharpen(img, config.sharpen)
+	}
+
+	if config.blur != 0 {
+		img = imaging.Blur(img, config.blur)
+	}
 
-	return resize.Resize(uint(width), height, img, resize.Lanczos3)
+	return img
 }
 
 func mirrorImage(img image.Image, mirrorMode MirrorMode) image.Image {
@@ -219,64 +623,510 @@ func mirrorImage(img image.Image, mirrorMode MirrorMode) image.Image {
 	return mirroredImg
 }
 
-// Converts RGB values to an ASCII character and color values.
-//
-// Uses standard luminance formula to map brightness to one of 8 ASCII chars.
-// Returns the character and 8-bit RGB values for terminal output.
-func processPixel(r, g, b uint32, colourMode ColourMode) (char byte, pR, pG, pB uint8) {
-	// Convert to grayscale (0.0 to 1.0) and divide by 2^16
-	gray := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 65536
-
-	// Get ASCII character based on brightness
-	charIndex := int(gray * float64(len(asciiChars)-1))
+// Maps brightness to an index into asciiChars. luminance stays below 1, so
+// even full-white pixels land on the second-to-last character; the clamp
+// only guards the upper bound.
+func charIndexForLuminance(r, g, b uint32) int {
+	charIndex := int(luminance(r, g, b) * float64(len(asciiChars)-1))
 	if charIndex >= len(asciiChars) {
 		charIndex = len(asciiChars) - 1
 	}
-	char = asciiChars[charIndex]
 
-	// Convert to 8-bit RGB for color processing
+	return charIndex
+}
+
+// Resolves the 8-bit RGB a pixel should be rendered in for colourMode.
+// charIndex is the bucket this pixel falls into on the brightness ramp,
+// used by Random mode to keep same-brightness pixels colour-consistent.
+// Shared by every rendering backend (brightness ramp, edges, braille,
+// halfblock) so they stay in sync on how each colour mode is interpreted.
+func pixelRGB8(r, g, b uint32, charIndex int, colourMode ColourMode) (pR, pG, pB uint8) {
 	pR, pG, pB = uint8(r>>8), uint8(g>>8), uint8(b>>8)
 
-	if colourMode == Random {
+	switch colourMode {
+	case Random:
 		colors := randColourMap[charIndex]
 		pR, pG, pB = colors[0], colors[1], colors[2]
+	case Palette:
+		pR, pG, pB = nearestPaletteColour(activePalette, pR, pG, pB)
+	case Posterized:
+		pR, pG, pB = posterizePixel(r, g, b)
 	}
 
-	return char, pR, pG, pB
+	return pR, pG, pB
 }
 
-// Converts an image to colored ASCII art string.
+// Converts RGB values to an ASCII character and color values.
 //
-// Processes each pixel to determine ASCII character and color, then formats
-// with ANSI color codes based on the specified color mode.
-func imageToASCII(img image.Image, colourMode ColourMode) string {
+// Uses standard luminance formula to map brightness to one of 8 ASCII chars.
+// Returns the character and 8-bit RGB values for terminal output.
+func processPixel(r, g, b uint32, colourMode ColourMode) (char byte, pR, pG, pB uint8) {
+	charIndex := charIndexForLuminance(r, g, b)
+	char = asciiChars[charIndex]
+	pR, pG, pB = pixelRGB8(r, g, b, charIndex, colourMode)
+
+	return char, pR, pG, pB
+}
+
+// One rendered terminal cell: a glyph plus the foreground (and, for
+// halfblock mode, background) colour it should be drawn in. coloured is
+// false for Monochrome mode, where sinks should omit colour entirely.
+type asciiCell struct {
+	glyph         rune
+	fgR, fgG, fgB uint8
+	bgR, bgG, bgB uint8
+	hasBG         bool
+	coloured      bool
+}
+
+// A rendered frame as a grid of cells, independent of any output format.
+// buildGrid produces one of these; the encode* functions turn it into the
+// bytes for a specific Sink.
+type asciiGrid struct {
+	cols, rows int
+	cells      []asciiCell // row-major, len == cols*rows
+}
+
+func newASCIIGrid(cols, rows int) asciiGrid {
+	return asciiGrid{cols: cols, rows: rows, cells: make([]asciiCell, cols*rows)}
+}
+
+func (g *asciiGrid) set(x, y int, cell asciiCell) {
+	g.cells[y*g.cols+x] = cell
+}
+
+// Builds the cell grid for an image, picking the backend from config.
+// charset takes priority over edgesMode: braille and halfblock render
+// their own glyphs and don't mix with the edge-detected brightness ramp.
+func buildGrid(img image.Image, config Config) asciiGrid {
+	switch config.charset {
+	case CharsetBraille:
+		return buildBrailleGrid(img, config.colourMode, config.brailleThreshold)
+	case CharsetHalfblock:
+		return buildHalfblockGrid(img, config.colourMode)
+	}
+
+	if config.edgesMode {
+		return buildEdgeGrid(img, config.colourMode, config.edgeThreshold)
+	}
+
+	return buildBrightnessGrid(img, config.colourMode)
+}
+
+// Renders an image to an ANSI string for printing animation frames in
+// place in the terminal.
+func renderASCII(img image.Image, config Config) string {
+	return string(encodeANSI(buildGrid(img, config)))
+}
+
+// Renders an image to bytes in config's configured --format, for writing
+// to a Sink. Without --output the frame goes to the terminal and is always
+// ANSI.
+func renderImage(img image.Image, config Config) []byte {
+	grid := buildGrid(img, config)
+
+	if config.outputPath == "" {
+		return encodeANSI(grid)
+	}
+
+	switch config.outputFormat {
+	case FormatHTML:
+		return encodeHTML(grid)
+	case FormatSVG:
+		return encodeSVG(grid)
+	case FormatTXT:
+		return encodeTXT(grid)
+	default:
+		return encodeANSI(grid)
+	}
+}
+
+// Builds the cell grid for the brightness-ramp backend: one ASCII
+// character per pixel, picked from the pixel's brightness.
+func buildBrightnessGrid(img image.Image, colourMode ColourMode) asciiGrid {
 	bounds := img.Bounds()
-	reset := string(colours.Reset)
-	var result strings.Builder
+	grid := newASCIIGrid(bounds.Dx(), bounds.Dy())
 
 	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
 		for x := bounds.Min.X; x < bounds.Max.X; x++ {
 			r, g, b, _ := img.At(x, y).RGBA()
 			char, pR, pG, pB := processPixel(r, g, b, colourMode)
+			grid.set(x-bounds.Min.X, y-bounds.Min.Y, asciiCell{
+				glyph: rune(char), fgR: pR, fgG: pG, fgB: pB, coloured: colourMode != Monochrome,
+			})
+		}
+	}
 
-			if colourMode == Monochrome {
-				result.WriteByte(char)
-				continue
+	return grid
+}
+
+// Builds the cell grid for edge-detected mode: a Sobel operator picks a
+// directional glyph (| / - \) from the gradient angle instead of the
+// brightness ramp. Pixels whose gradient magnitude falls below threshold
+// fall back to the regular brightness-ramp character, giving crisp line
+// art on high-contrast images where the ramp alone mushes everything
+// together.
+func buildEdgeGrid(img image.Image, colourMode ColourMode, threshold float64) asciiGrid {
+	bounds := img.Bounds()
+	grid := newASCIIGrid(bounds.Dx(), bounds.Dy())
+
+	luminanceAt := func(x, y int) float64 {
+		x = clampInt(x, bounds.Min.X, bounds.Max.X-1)
+		y = clampInt(y, bounds.Min.Y, bounds.Max.Y-1)
+		r, g, b, _ := img.At(x, y).RGBA()
+		return luminance(r, g, b) * 255
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			gx := (luminanceAt(x+1, y-1) + 2*luminanceAt(x+1, y) + luminanceAt(x+1, y+1)) -
+				(luminanceAt(x-1, y-1) + 2*luminanceAt(x-1, y) + luminanceAt(x-1, y+1))
+			gy := (luminanceAt(x-1, y+1) + 2*luminanceAt(x, y+1) + luminanceAt(x+1, y+1)) -
+				(luminanceAt(x-1, y-1) + 2*luminanceAt(x, y-1) + luminanceAt(x+1, y-1))
+			magnitude := math.Sqrt(gx*gx + gy*gy)
+
+			r, g, b, _ := img.At(x, y).RGBA()
+			char, pR, pG, pB := processPixel(r, g, b, colourMode)
+
+			if magnitude >= threshold {
+				// The Sobel gradient points across the edge, not along it,
+				// so rotate a quarter turn before picking the line glyph.
+				// gy grows downwards (image rows), so it is negated to get a
+				// counter-clockwise angle as seen on screen.
+				char = edgeGlyph(math.Atan2(-gy, gx) + math.Pi/2)
 			}
 
-			if colourMode == Posterized {
-				pR, pG, pB = posterizePixel(r, g, b)
+			grid.set(x-bounds.Min.X, y-bounds.Min.Y, asciiCell{
+				glyph: rune(char), fgR: pR, fgG: pG, fgB: pB, coloured: colourMode != Monochrome,
+			})
+		}
+	}
+
+	return grid
+}
+
+// Builds the cell grid for Braille mode, treating each 2x4 block of
+// pixels as one Braille cell (a block cut short by the image's bottom
+// edge uses only the pixels present): each of the cell's 8 dots is set
+// when its pixel's luminance is above threshold. A negative threshold means
+// "compute one via Otsu's method for this image" rather than using a
+// fixed cutoff.
+//
+// Cell colour comes from the average of its up-to-8 source pixels, run
+// through the same colour-mode handling as every other backend.
+func buildBrailleGrid(img image.Image, colourMode ColourMode, threshold float64) asciiGrid {
+	bounds := img.Bounds()
+
+	if threshold < 0 {
+		threshold = otsuThreshold(img)
+	}
+
+	cellsX := (bounds.Dx() + 1) / 2
+	cellsY := (bounds.Dy() + 3) / 4
+	grid := newASCIIGrid(cellsX, cellsY)
+
+	// Column, row within the 2x4 cell for each of the 8 Braille dots, in
+	// Unicode dot-numbering order (1,2,3,4,5,6,7,8).
+	dotOffsets := [8][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {0, 3}, {1, 3}}
+
+	for cy := 0; cy < cellsY; cy++ {
+		for cx := 0; cx < cellsX; cx++ {
+			var bits byte
+			var sumR, sumG, sumB float64
+			count := 0
+
+			for dot, offset := range dotOffsets {
+				x := bounds.Min.X + cx*2 + offset[0]
+				y := bounds.Min.Y + cy*4 + offset[1]
+				if x >= bounds.Max.X || y >= bounds.Max.Y {
+					continue
+				}
+
+				r, g, b, _ := img.At(x, y).RGBA()
+				sumR += float64(r >> 8)
+				sumG += float64(g >> 8)
+				sumB += float64(b >> 8)
+				count++
+
+				if luminance(r, g, b)*255 > threshold {
+					bits |= 1 << uint(dot)
+				}
+			}
+
+			glyph := rune(0x2800 + int(bits))
+
+			var pR, pG, pB uint8
+			if count > 0 {
+				avgR := uint32(sumR/float64(count)) << 8
+				avgG := uint32(sumG/float64(count)) << 8
+				avgB := uint32(sumB/float64(count)) << 8
+				pR, pG, pB = pixelRGB8(avgR, avgG, avgB, charIndexForLuminance(avgR, avgG, avgB), colourMode)
 			}
 
-			colourCode := colours.RGB2ANSI(pR, pG, pB)
-			result.WriteString(colourCode)
-			result.WriteByte(char)
+			grid.set(cx, cy, asciiCell{glyph: glyph, fgR: pR, fgG: pG, fgB: pB, coloured: colourMode != Monochrome})
+		}
+	}
+
+	return grid
+}
+
+// Builds the cell grid for half-block mode: each cell is a U+2580 "▀"
+// with the top source pixel as foreground and the bottom as background,
+// doubling vertical resolution versus one pixel per cell. When the image
+// has an odd height, the last row's bottom half repeats its top pixel.
+func buildHalfblockGrid(img image.Image, colourMode ColourMode) asciiGrid {
+	bounds := img.Bounds()
+	grid := newASCIIGrid(bounds.Dx(), (bounds.Dy()+1)/2)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			topR, topG, topB, _ := img.At(x, y).RGBA()
+
+			botR, botG, botB := topR, topG, topB
+			if y+1 < bounds.Max.Y {
+				botR, botG, botB, _ = img.At(x, y+1).RGBA()
+			}
+
+			cell := asciiCell{glyph: '▀', hasBG: true, coloured: colourMode != Monochrome}
+			cell.fgR, cell.fgG, cell.fgB = pixelRGB8(topR, topG, topB, charIndexForLuminance(topR, topG, topB), colourMode)
+			cell.bgR, cell.bgG, cell.bgB = pixelRGB8(botR, botG, botB, charIndexForLuminance(botR, botG, botB), colourMode)
+
+			grid.set(x-bounds.Min.X, (y-bounds.Min.Y)/2, cell)
+		}
+	}
+
+	return grid
+}
+
+// Encodes a grid as a raw ANSI-escaped byte stream, the format used for
+// interactive terminal display and the "ansi" --format.
+func encodeANSI(grid asciiGrid) []byte {
+	reset := string(colours.Reset)
+	var result strings.Builder
+
+	for y := 0; y < grid.rows; y++ {
+		for x := 0; x < grid.cols; x++ {
+			cell := grid.cells[y*grid.cols+x]
+
+			if !cell.coloured {
+				result.WriteRune(cell.glyph)
+				continue
+			}
+
+			result.WriteString(colours.RGB2ANSI(cell.fgR, cell.fgG, cell.fgB))
+			if cell.hasBG {
+				fmt.Fprintf(&result, "\x1b[48;2;%d;%d;%dm", cell.bgR, cell.bgG, cell.bgB)
+			}
+			result.WriteRune(cell.glyph)
 			result.WriteString(reset)
 		}
 		result.WriteByte('\n')
 	}
 
-	return result.String()
+	return []byte(result.String())
+}
+
+// Encodes a grid as plain text with no colour information at all.
+func encodeTXT(grid asciiGrid) []byte {
+	var result strings.Builder
+
+	for y := 0; y < grid.rows; y++ {
+		for x := 0; x < grid.cols; x++ {
+			result.WriteRune(grid.cells[y*grid.cols+x].glyph)
+		}
+		result.WriteByte('\n')
+	}
+
+	return []byte(result.String())
+}
+
+// Encodes a grid as a standalone HTML document: one <pre> block with a
+// monospace font, each coloured cell wrapped in its own <span>.
+func encodeHTML(grid asciiGrid) []byte {
+	var result strings.Builder
+
+	result.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"></head>\n")
+	result.WriteString("<body style=\"background:#000\">\n<pre style=\"font-family:monospace;line-height:1\">\n")
+
+	for y := 0; y < grid.rows; y++ {
+		for x := 0; x < grid.cols; x++ {
+			cell := grid.cells[y*grid.cols+x]
+
+			if !cell.coloured {
+				result.WriteString(htmlEscapeGlyph(cell.glyph))
+				continue
+			}
+
+			style := fmt.Sprintf("color:#%02x%02x%02x", cell.fgR, cell.fgG, cell.fgB)
+			if cell.hasBG {
+				style += fmt.Sprintf(";background-color:#%02x%02x%02x", cell.bgR, cell.bgG, cell.bgB)
+			}
+
+			fmt.Fprintf(&result, "<span style=\"%s\">%s</span>", style, htmlEscapeGlyph(cell.glyph))
+		}
+		result.WriteByte('\n')
+	}
+
+	result.WriteString("</pre>\n</body></html>\n")
+
+	return []byte(result.String())
+}
+
+// Escapes the handful of glyphs that are meaningful in HTML; every glyph
+// this program emits is otherwise safe to write as-is.
+func htmlEscapeGlyph(glyph rune) string {
+	switch glyph {
+	case '<':
+		return "&lt;"
+	case '>':
+		return "&gt;"
+	case '&':
+		return "&amp;"
+	default:
+		return string(glyph)
+	}
+}
+
+// Pixel dimensions of one cell in the SVG grid: wide enough for a
+// monospace glyph at svgFontSize, tall enough to keep rows from
+// overlapping.
+const (
+	svgCellWidth  = 9
+	svgCellHeight = 16
+	svgFontSize   = 16
+)
+
+// Encodes a grid as an SVG document, one <text> element per non-space cell at fixed
+// grid coordinates, so the art can be embedded on the web at any zoom
+// without losing crispness. Cells with a background (e.g. halfblock mode)
+// get a <rect> behind the glyph, matching the HTML encoder's handling.
+func encodeSVG(grid asciiGrid) []byte {
+	width := grid.cols * svgCellWidth
+	height := grid.rows * svgCellHeight
+
+	var result strings.Builder
+
+	fmt.Fprintf(&result, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"monospace\" font-size=\"%d\">\n", width, height, svgFontSize)
+	fmt.Fprintf(&result, "<rect width=\"100%%\" height=\"100%%\" fill=\"#000\"/>\n")
+
+	for y := 0; y < grid.rows; y++ {
+		for x := 0; x < grid.cols; x++ {
+			cell := grid.cells[y*grid.cols+x]
+			px := x * svgCellWidth
+			py := y * svgCellHeight
+
+			if cell.hasBG && cell.coloured {
+				bg := fmt.Sprintf("#%02x%02x%02x", cell.bgR, cell.bgG, cell.bgB)
+				fmt.Fprintf(&result, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", px, py, svgCellWidth, svgCellHeight, bg)
+			}
+
+			if cell.glyph == ' ' {
+				continue
+			}
+
+			fill := "#ffffff"
+			if cell.coloured {
+				fill = fmt.Sprintf("#%02x%02x%02x", cell.fgR, cell.fgG, cell.fgB)
+			}
+
+			fmt.Fprintf(&result, "<text x=\"%d\" y=\"%d\" fill=\"%s\">%s</text>\n", px, py+svgFontSize, fill, htmlEscapeGlyph(cell.glyph))
+		}
+	}
+
+	result.WriteString("</svg>\n")
+
+	return []byte(result.String())
+}
+
+// Computes a global binarization threshold for pixel luminance (0..255)
+// using Otsu's method: the threshold that maximizes the between-class
+// variance of the resulting "dot on"/"dot off" split. Used by braille
+// mode when no fixed threshold is configured.
+func otsuThreshold(img image.Image) float64 {
+	var histogram [256]int
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			level := int(luminance(r, g, b) * 255)
+			if level > 255 {
+				level = 255
+			}
+			histogram[level]++
+		}
+	}
+
+	total := bounds.Dx() * bounds.Dy()
+
+	var sum float64
+	for level, count := range histogram {
+		sum += float64(level * count)
+	}
+
+	var sumBackground, weightBackground, bestVariance, bestThreshold float64
+
+	for level, count := range histogram {
+		weightBackground += float64(count)
+		if weightBackground == 0 {
+			continue
+		}
+
+		weightForeground := float64(total) - weightBackground
+		if weightForeground == 0 {
+			break
+		}
+
+		sumBackground += float64(level * count)
+		meanBackground := sumBackground / weightBackground
+		meanForeground := (sum - sumBackground) / weightForeground
+
+		variance := weightBackground * weightForeground * (meanBackground - meanForeground) * (meanBackground - meanForeground)
+		if variance > bestVariance {
+			bestVariance = variance
+			bestThreshold = float64(level)
+		}
+	}
+
+	return bestThreshold
+}
+
+// Maps a line angle (radians, counter-clockwise from the positive x axis
+// as seen on screen) to one of four directional glyphs.
+// Edge direction is line-symmetric, so the angle is first folded into the
+// range 0 to π before bucketing into 45°-wide ranges.
+func edgeGlyph(theta float64) byte {
+	theta = math.Mod(theta, math.Pi)
+	if theta < 0 {
+		theta += math.Pi
+	}
+
+	switch {
+	case theta < math.Pi/8, theta >= 7*math.Pi/8:
+		return '-'
+	case theta < 3*math.Pi/8:
+		return '/'
+	case theta < 5*math.Pi/8:
+		return '|'
+	default:
+		return '\\'
+	}
+}
+
+// Standard luminance formula, returning brightness in [0, 1].
+func luminance(r, g, b uint32) float64 {
+	return (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 65536
+}
+
+// Clamps v to the inclusive range [lo, hi].
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
 }
 
 // Convert colour to 8-bit and Posterize each channel to 2 levels
@@ -294,9 +1144,140 @@ func posterizePixel(r, g, b uint32) (uint8, uint8, uint8) {
 	return posterR, posterG, posterB
 }
 
+// Finds the palette entry closest to (r, g, b) by squared Euclidean
+// distance in RGB space, consulting paletteLookup first so repeated
+// pixel values skip the linear scan.
+func nearestPaletteColour(palette color.Palette, r, g, b uint8) (uint8, uint8, uint8) {
+	key := uint32(r)<<16 | uint32(g)<<8 | uint32(b)
+
+	if cached, ok := paletteLookup.get(key); ok {
+		return rgb8(cached)
+	}
+
+	best := palette[0]
+	bestDist := squaredRGBDistance(best, r, g, b)
+
+	for _, candidate := range palette[1:] {
+		if d := squaredRGBDistance(candidate, r, g, b); d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+
+	paletteLookup.put(key, best)
+
+	return rgb8(best)
+}
+
+// Squared Euclidean distance between c and (r, g, b) in 8-bit RGB space.
+func squaredRGBDistance(c color.Color, r, g, b uint8) int {
+	cr, cg, cb := rgb8(c)
+	dr, dg, db := int(r)-int(cr), int(g)-int(cg), int(b)-int(cb)
+
+	return dr*dr + dg*dg + db*db
+}
+
+// Downsamples a color.Color's 16-bit channels to 8-bit RGB.
+func rgb8(c color.Color) (uint8, uint8, uint8) {
+	r, g, b, _ := c.RGBA()
+	return uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)
+}
+
+// A fixed-size least-recently-used cache mapping an 8-bit RGB triple
+// (packed as 0xRRGGBB) to its nearest palette colour.
+type paletteCache struct {
+	capacity int
+	order    *list.List
+	entries  map[uint32]*list.Element
+}
+
+type paletteCacheEntry struct {
+	key   uint32
+	value color.Color
+}
+
+func newPaletteCache(capacity int) *paletteCache {
+	return &paletteCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[uint32]*list.Element, capacity),
+	}
+}
+
+func (c *paletteCache) get(key uint32) (color.Color, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+
+	return el.Value.(*paletteCacheEntry).value, true
+}
+
+func (c *paletteCache) put(key uint32, value color.Color) {
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*paletteCacheEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&paletteCacheEntry{key: key, value: value})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*paletteCacheEntry).key)
+	}
+}
+
+// Loads a palette from a file containing one "#RRGGBB" colour per line.
+func loadPaletteFile(path string) (color.Palette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var palette color.Palette
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		c, err := parseHexColour(line)
+		if err != nil {
+			return nil, fmt.Errorf("palette file %s: %w", path, err)
+		}
+
+		palette = append(palette, c)
+	}
+
+	if len(palette) == 0 {
+		return nil, fmt.Errorf("palette file %s: contains no colours", path)
+	}
+
+	return palette, nil
+}
+
+// Parses a "#RRGGBB" string into an 8-bit RGBA colour.
+func parseHexColour(s string) (color.RGBA, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return color.RGBA{}, fmt.Errorf("%q is not a 6-digit hex colour", s)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, nil
+}
+
 // Scans a directory for image files and returns their full paths.
 //
-// Only includes .jpg, .jpeg, and .png files.
+// Only includes .jpg, .jpeg, .png, and .gif files.
 func getImagesInFolder(config Config) []string {
 	files, _ := os.ReadDir(config.folderPath)
 	var imageFiles []string
@@ -308,7 +1289,7 @@ func getImagesInFolder(config Config) []string {
 
 		ext := strings.ToLower(filepath.Ext(file.Name()))
 
-		if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
+		if ext != ".jpg" && ext != ".jpeg" && ext != ".png" && ext != ".gif" {
 			continue
 		}
 
@@ -331,13 +1312,18 @@ func generateRandColourMap() {
 // Parses command-line arguments and returns a Config struct
 func parseArguments() Config {
 	config := Config{
-		imageFile:  "default",
-		folderPath: "",
-		imageWidth: 80,
-		colourMode: Posterized,
-		loopMode:   false,
-		printSpeed: 1000,
-		mirrorMode: MirrorNone,
+		imageFile:        "default",
+		folderPath:       "",
+		imageWidth:       80,
+		colourMode:       Posterized,
+		loopMode:         false,
+		printSpeed:       1000,
+		mirrorMode:       MirrorNone,
+		resampleFilter:   imaging.Lanczos,
+		gamma:            1,
+		edgeThreshold:    40,
+		charset:          CharsetASCII,
+		brailleThreshold: -1,
 	}
 
 	if len(os.Args) < 1 {
@@ -355,6 +1341,9 @@ func parseArguments() Config {
 	parser.String("i", "image", &config.imageFile)
 	parser.String("f", "folder", &config.folderPath)
 
+	// Output
+	parser.String("o", "output", &config.outputPath)
+
 	// Colour flags
 	if parser.HasFlag("m", "monochrome") {
 		config.colourMode = Monochrome
@@ -370,6 +1359,7 @@ func parseArguments() Config {
 	parser.Integer("w", "width", &config.imageWidth)
 	parser.Integer("s", "speed", &config.printSpeed)
 	parser.Bool("l", "loop", &config.loopMode)
+	parser.Bool("e", "edges", &config.edgesMode)
 
 	// Enum Options
 	if value, ok := parser.GetStringValue("mr", "mirror"); ok {
@@ -384,6 +1374,131 @@ func parseArguments() Config {
 		}
 	}
 
+	// Pre-ASCII adjustment pipeline
+	if value, ok := parser.GetStringValue("br", "brightness"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --brightness: %s\n", err)
+			os.Exit(1)
+		}
+		config.brightness = f
+	}
+
+	if value, ok := parser.GetStringValue("ct", "contrast"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --contrast: %s\n", err)
+			os.Exit(1)
+		}
+		config.contrast = f
+	}
+
+	if value, ok := parser.GetStringValue("ga", "gamma"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --gamma: %s\n", err)
+			os.Exit(1)
+		}
+		config.gamma = f
+	}
+
+	if value, ok := parser.GetStringValue("sa", "saturation"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --saturation: %s\n", err)
+			os.Exit(1)
+		}
+		config.saturation = f
+	}
+
+	if value, ok := parser.GetStringValue("sh", "sharpen"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --sharpen: %s\n", err)
+			os.Exit(1)
+		}
+		config.sharpen = f
+	}
+
+	if value, ok := parser.GetStringValue("bl", "blur"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --blur: %s\n", err)
+			os.Exit(1)
+		}
+		config.blur = f
+	}
+
+	if value, ok := parser.GetStringValue("et", "edge-threshold"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --edge-threshold: %s\n", err)
+			os.Exit(1)
+		}
+		config.edgeThreshold = f
+	}
+
+	if value, ok := parser.GetStringValue("cs", "charset"); ok {
+		charset, ok := charsets[value]
+		if !ok {
+			fmt.Printf("Error: unknown charset %q\n", value)
+			os.Exit(1)
+		}
+
+		config.charset = charset
+	}
+
+	if value, ok := parser.GetStringValue("bt", "braille-threshold"); ok {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("Error: invalid value for --braille-threshold: %s\n", err)
+			os.Exit(1)
+		}
+		config.brailleThreshold = f
+	}
+
+	if value, ok := parser.GetStringValue("ft", "filter"); ok {
+		filter, ok := resampleFilters[value]
+		if !ok {
+			fmt.Printf("Error: unknown filter %q\n", value)
+			os.Exit(1)
+		}
+
+		config.resampleFilter = filter
+	}
+
+	if value, ok := parser.GetStringValue("fo", "format"); ok {
+		format, ok := outputFormats[value]
+		if !ok {
+			fmt.Printf("Error: unknown format %q\n", value)
+			os.Exit(1)
+		}
+
+		config.outputFormat = format
+	}
+
+	if value, ok := parser.GetStringValue("pl", "palette"); ok {
+		palette, ok := namedPalettes[value]
+		if !ok {
+			fmt.Printf("Error: unknown palette %q\n", value)
+			os.Exit(1)
+		}
+
+		config.colourMode = Palette
+		activePalette = palette
+	}
+
+	if value, ok := parser.GetStringValue("pf", "palette-file"); ok {
+		palette, err := loadPaletteFile(value)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			os.Exit(1)
+		}
+
+		config.colourMode = Palette
+		activePalette = palette
+	}
+
 	// Validation
 	if err := parser.ValidateArgs(); err != nil {
 		fmt.Printf("Error: %s\n", err)
@@ -405,18 +1520,37 @@ func printHelpMessage() {
 	fmt.Println("INPUT:")
 	fmt.Println("  -i, --image <file>     Image file to convert (default: Kim Kitsuragi)")
 	fmt.Println("  -f, --folder <path>    When used, will print all images in the given folder")
+	fmt.Println("                         Animated GIFs are played frame-by-frame in place")
+	fmt.Println("                         JPEGs are auto-rotated per their EXIF orientation tag")
+	fmt.Println()
+	fmt.Println("OUTPUT:")
+	fmt.Println("  -o, --output <path>    Write to a file instead of the terminal")
+	fmt.Println("  -fo, --format <name>   Output format: ansi, html, svg, txt (default: ansi)")
 	fmt.Println()
 	fmt.Println("OPTIONS:")
 	fmt.Println("  -w, --width <number>   Width of ASCII output in characters (default: 80)")
 	fmt.Println("  -l, --loop             Enable Loop Mode, which prints the image forever (default: false)")
 	fmt.Println("  -s, --speed            The speed of printing, in chars per second (default: 1000)")
 	fmt.Println("  -mr, --mirror <mode>   Mirror the image: x, y, or xy (default: none)")
+	fmt.Println("  -ft, --filter <name>   Resample filter: lanczos, catmullrom, linear, nearestneighbor, box (default: lanczos)")
+	fmt.Println("  -br, --brightness <-100..100>  Adjust brightness before conversion (default: 0)")
+	fmt.Println("  -ct, --contrast <-100..100>    Adjust contrast before conversion (default: 0)")
+	fmt.Println("  -ga, --gamma <float>           Adjust gamma before conversion (default: 1)")
+	fmt.Println("  -sa, --saturation <-100..100>  Adjust saturation before conversion (default: 0)")
+	fmt.Println("  -sh, --sharpen <sigma>         Sharpen before conversion (default: 0, off)")
+	fmt.Println("  -bl, --blur <sigma>            Blur before conversion (default: 0, off)")
+	fmt.Println("  -e, --edges             Edge-detected mode: directional glyphs (| / - \\) instead of a brightness ramp")
+	fmt.Println("  -et, --edge-threshold <n>  Gradient magnitude below which edges mode falls back to the ramp (default: 40)")
+	fmt.Println("  -cs, --charset <name>  Rendering backend: ascii, braille, halfblock (default: ascii)")
+	fmt.Println("  -bt, --braille-threshold <n>  Luminance (0-255) above which a braille dot is set (default: auto, via Otsu)")
 	fmt.Println()
 	fmt.Println("COLOR MODES:")
 	fmt.Println("  -m, --monochrome       Black and white ASCII art")
 	fmt.Println("  -p, --posterized       8-color posterized ASCII art (default)")
 	fmt.Println("  -c, --colourful        Full-color ASCII art")
 	fmt.Println("  -r, --random           Randomised colours!")
+	fmt.Println("  -pl, --palette <name>  Quantize to a named palette: cga, ega, gruvbox, solarized, nord")
+	fmt.Println("  -pf, --palette-file <path>  Quantize to a palette loaded from a file of #RRGGBB lines")
 	fmt.Println()
 	fmt.Println("  -h, --help             Show this help message")
 	fmt.Println()